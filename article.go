@@ -0,0 +1,14 @@
+package nntp
+
+import (
+	"io"
+	"net/textproto"
+)
+
+// Article is a single NNTP article with its headers already parsed,
+// sparing callers from re-implementing RFC 822 header parsing on top
+// of the raw reader returned by Article/Head/Body-style commands.
+type Article struct {
+	Header textproto.MIMEHeader
+	Body   io.Reader
+}
@@ -0,0 +1,99 @@
+package nntpclient
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Capabilities describes what an NNTP server advertises in response to
+// the CAPABILITIES command.
+type Capabilities struct {
+	Version int
+	// ReaderMode is true if the server advertised READER.
+	ReaderMode bool
+	// PostAllowed is true if the server advertised POST.
+	PostAllowed bool
+	// IHAVE is true if the server advertised IHAVE.
+	IHAVE bool
+	// Streaming is true if the server advertised STREAMING (CHECK and
+	// TAKETHIS pipelining).
+	Streaming bool
+	// StartTLS is true if the server advertised STARTTLS.
+	StartTLS bool
+	// Compress lists the compression schemes advertised by the COMPRESS
+	// capability, e.g. "GZIP".
+	Compress []string
+	// SASL lists the mechanisms advertised by the SASL capability.
+	SASL []string
+	// OverviewFmt lists the fields advertised by the OVER capability.
+	OverviewFmt []string
+	// XZVER is true if the server advertised support for the XZVER
+	// verb, the per-command zlib-compressed form of XOVER. This is
+	// unrelated to Compress, which covers session-wide compression of
+	// the whole connection.
+	XZVER bool
+	// Extra holds every capability line that wasn't recognized above,
+	// verbatim and in the order the server sent it.
+	Extra []string
+}
+
+// Capabilities returns the server's advertised capabilities, issuing
+// CAPABILITIES and caching the result the first time it's called.  Use
+// RefreshCapabilities to force a re-read.
+func (c *Client) Capabilities() (Capabilities, error) {
+	if c.caps != nil {
+		return *c.caps, nil
+	}
+	return c.RefreshCapabilities()
+}
+
+// RefreshCapabilities reissues CAPABILITIES and replaces the cached value.
+func (c *Client) RefreshCapabilities() (Capabilities, error) {
+	_, lines, err := c.MultilineCommand("CAPABILITIES", 101)
+	if err != nil {
+		return Capabilities{}, err
+	}
+	// lines[0] is the status line itself; the capability list follows.
+	caps := parseCapabilities(lines[1:])
+	c.caps = &caps
+	return caps, nil
+}
+
+func parseCapabilities(lines []string) Capabilities {
+	var caps Capabilities
+	for _, l := range lines {
+		fields := strings.Fields(l)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "VERSION":
+			if len(fields) > 1 {
+				if v, err := strconv.Atoi(fields[1]); err == nil {
+					caps.Version = v
+				}
+			}
+		case "READER":
+			caps.ReaderMode = true
+		case "POST":
+			caps.PostAllowed = true
+		case "IHAVE":
+			caps.IHAVE = true
+		case "STREAMING":
+			caps.Streaming = true
+		case "STARTTLS":
+			caps.StartTLS = true
+		case "COMPRESS":
+			caps.Compress = fields[1:]
+		case "SASL":
+			caps.SASL = fields[1:]
+		case "OVER":
+			caps.OverviewFmt = fields[1:]
+		case "XZVER":
+			caps.XZVER = true
+		default:
+			caps.Extra = append(caps.Extra, l)
+		}
+	}
+	return caps
+}
@@ -0,0 +1,70 @@
+package nntpclient
+
+import (
+	"net"
+	"net/textproto"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestClient wires a Client to one end of a net.Pipe and returns
+// the other end as a textproto.Conn a test can drive as the server.
+func newTestClient(t *testing.T) (*Client, *textproto.Conn, func()) {
+	t.Helper()
+	clientConn, serverConn := net.Pipe()
+	c := &Client{conn: textproto.NewConn(clientConn), netConn: clientConn}
+	server := textproto.NewConn(serverConn)
+	return c, server, func() { clientConn.Close(); serverConn.Close() }
+}
+
+func TestRefreshCapabilities(t *testing.T) {
+	c, server, closeAll := newTestClient(t)
+	defer closeAll()
+
+	go func() {
+		if _, err := server.ReadLine(); err != nil {
+			return
+		}
+		server.PrintfLine("101 Capability list:")
+		server.PrintfLine("VERSION 2")
+		server.PrintfLine("READER")
+		server.PrintfLine("STREAMING")
+		server.PrintfLine("STARTTLS")
+		server.PrintfLine("COMPRESS GZIP")
+		server.PrintfLine("SASL PLAIN")
+		server.PrintfLine("XZVER")
+		server.PrintfLine("IMPLEMENTATION example")
+		server.PrintfLine(".")
+	}()
+
+	done := make(chan struct{})
+	var caps Capabilities
+	var err error
+	go func() {
+		caps, err = c.RefreshCapabilities()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for RefreshCapabilities")
+	}
+	if err != nil {
+		t.Fatalf("RefreshCapabilities: %v", err)
+	}
+
+	want := Capabilities{
+		Version:    2,
+		ReaderMode: true,
+		Streaming:  true,
+		StartTLS:   true,
+		Compress:   []string{"GZIP"},
+		SASL:       []string{"PLAIN"},
+		XZVER:      true,
+		Extra:      []string{"IMPLEMENTATION example"},
+	}
+	if !reflect.DeepEqual(caps, want) {
+		t.Errorf("RefreshCapabilities() = %+v, want %+v", caps, want)
+	}
+}
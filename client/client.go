@@ -6,6 +6,7 @@ import (
 	"compress/zlib"
 	"errors"
 	"io"
+	"net"
 	"net/textproto"
 	"strconv"
 	"strings"
@@ -16,16 +17,32 @@ import (
 
 // Client is an NNTP client.
 type Client struct {
-	conn   *textproto.Conn
-	Banner string
+	conn    *textproto.Conn
+	netConn net.Conn
+	Banner  string
+	// MustEncrypt, when true, causes Authenticate to refuse to send
+	// AUTHINFO credentials unless the connection has been upgraded to
+	// TLS via DialTLS or StartTLS.
+	MustEncrypt bool
+
+	// caps caches the result of the last CAPABILITIES call; see
+	// Capabilities and RefreshCapabilities.
+	caps *Capabilities
+
+	// currentGroup and currentArticle track the session's cursor,
+	// updated by Group, Next, Last, Article, Stat and ListGroup; see CurrentGroup
+	// and CurrentArticle.
+	currentGroup   string
+	currentArticle int64
 }
 
 // New connects a client to an NNTP server.
-func New(net, addr string) (*Client, error) {
-	conn, err := textproto.Dial(net, addr)
+func New(network, addr string) (*Client, error) {
+	netConn, err := net.Dial(network, addr)
 	if err != nil {
 		return nil, err
 	}
+	conn := textproto.NewConn(netConn)
 
 	_, msg, err := conn.ReadCodeLine(200)
 	if err != nil {
@@ -33,8 +50,9 @@ func New(net, addr string) (*Client, error) {
 	}
 
 	return &Client{
-		conn:   conn,
-		Banner: msg,
+		conn:    conn,
+		netConn: netConn,
+		Banner:  msg,
 	}, nil
 }
 
@@ -43,8 +61,17 @@ func (c *Client) Close() error {
 	return c.conn.Close()
 }
 
-// Authenticate against an NNTP server using authinfo user/pass
+// Authenticate against an NNTP server using authinfo user/pass, or
+// AUTHINFO SASL PLAIN if the server advertised it.
 func (c *Client) Authenticate(user, pass string) (msg string, err error) {
+	if c.MustEncrypt && !c.isEncrypted() {
+		return "", errors.New("nntpclient: MustEncrypt is set but connection is not using TLS")
+	}
+
+	if caps, capErr := c.Capabilities(); capErr == nil && hasSASLPlain(caps.SASL) {
+		return c.authenticateSASLPlain(user, pass)
+	}
+
 	err = c.conn.PrintfLine("authinfo user %s", user)
 	if err != nil {
 		return
@@ -59,6 +86,11 @@ func (c *Client) Authenticate(user, pass string) (msg string, err error) {
 		return
 	}
 	_, msg, err = c.conn.ReadCodeLine(281)
+	if err != nil {
+		return
+	}
+
+	_, err = c.RefreshCapabilities()
 	return
 }
 
@@ -85,21 +117,33 @@ func (c *Client) List(sub string) (rv []nntp.Group, err error) {
 	}
 	rv = make([]nntp.Group, 0, len(groupLines))
 	for _, l := range groupLines {
-		parts := strings.Split(l, " ")
-		high, errh := strconv.ParseInt(parts[1], 10, 64)
-		low, errl := strconv.ParseInt(parts[2], 10, 64)
-		if errh == nil && errl == nil {
-			rv = append(rv, nntp.Group{
-				Name:    parts[0],
-				High:    high,
-				Low:     low,
-				Posting: parsePosting(parts[3]),
-			})
+		if g, ok := parseGroupLine(l); ok {
+			rv = append(rv, g)
 		}
 	}
 	return
 }
 
+// parseGroupLine parses a single "group high low status" line, the
+// format shared by LIST and NEWGROUPS.
+func parseGroupLine(l string) (nntp.Group, bool) {
+	parts := strings.Split(l, " ")
+	if len(parts) != 4 {
+		return nntp.Group{}, false
+	}
+	high, errh := strconv.ParseInt(parts[1], 10, 64)
+	low, errl := strconv.ParseInt(parts[2], 10, 64)
+	if errh != nil || errl != nil {
+		return nntp.Group{}, false
+	}
+	return nntp.Group{
+		Name:    parts[0],
+		High:    high,
+		Low:     low,
+		Posting: parsePosting(parts[3]),
+	}, true
+}
+
 // Group selects a group.
 func (c *Client) Group(name string) (rv nntp.Group, err error) {
 	var msg string
@@ -126,6 +170,9 @@ func (c *Client) Group(name string) (rv nntp.Group, err error) {
 	}
 	rv.Name = parts[3]
 
+	c.currentGroup = rv.Name
+	c.currentArticle = rv.Low
+
 	return
 }
 
@@ -135,7 +182,11 @@ func (c *Client) Article(specifier string) (int64, string, io.Reader, error) {
 	if err != nil {
 		return 0, "", nil, err
 	}
-	return c.articleish(220)
+	n, msg, r, err := c.articleish(220)
+	if err == nil {
+		c.currentArticle = n
+	}
+	return n, msg, r, err
 }
 
 // Head gets the headers for an article
@@ -157,16 +208,28 @@ func (c *Client) Body(specifier string) (int64, string, io.Reader, error) {
 }
 
 func (c *Client) articleish(expected int) (int64, string, io.Reader, error) {
-	_, msg, err := c.conn.ReadCodeLine(expected)
+	n, msg, err := c.readArticleCode(expected)
 	if err != nil {
 		return 0, "", nil, err
 	}
+	return n, msg, c.conn.DotReader(), nil
+}
+
+// readArticleCode reads the status line shared by ARTICLE, HEAD and
+// BODY responses, of the form "<code> <article-number> <message-id>".
+// It returns the article number and the message-id; any text after the
+// message-id is dropped.
+func (c *Client) readArticleCode(expected int) (int64, string, error) {
+	_, msg, err := c.conn.ReadCodeLine(expected)
+	if err != nil {
+		return 0, "", err
+	}
 	parts := strings.SplitN(msg, " ", 2)
 	n, err := strconv.ParseInt(parts[0], 10, 64)
 	if err != nil {
-		return 0, "", nil, err
+		return 0, "", err
 	}
-	return n, parts[1], c.conn.DotReader(), nil
+	return n, strings.SplitN(parts[1], " ", 2)[0], nil
 }
 
 type Overview struct {
@@ -175,11 +238,16 @@ type Overview struct {
 }
 
 // XOver issues the XOVER verb across the range of messages specified in
-// specifier.  If compress is true, the XZVER verb will be used instead.
+// specifier.  If compress is true and the server advertised XZVER,
+// that verb is used instead.
 func (c *Client) XOver(specifier string, compress bool) (<-chan Overview, error) {
 	verb := "XOVER"
 	if compress {
-		verb = "XZVER"
+		if caps, err := c.Capabilities(); err == nil && caps.XZVER {
+			verb = "XZVER"
+		} else {
+			compress = false
+		}
 	}
 	headers := []string{"Article"}
 	headerFull := map[string]bool{}
@@ -0,0 +1,61 @@
+package nntpclient
+
+import (
+	"compress/gzip"
+	"net"
+	"net/textproto"
+)
+
+// gzipConn wraps the client's raw net.Conn in a gzip.Reader/gzip.Writer
+// pair, so the textproto.Conn built on top of it keeps working exactly
+// as it did over the plaintext socket. The underlying net.Conn is kept
+// around so Close can flush the gzip writer before closing the socket.
+type gzipConn struct {
+	net.Conn
+	zr *gzip.Reader
+	zw *gzip.Writer
+}
+
+func (g *gzipConn) Read(p []byte) (int, error) { return g.zr.Read(p) }
+
+// Write pushes p through the gzip writer and flushes immediately,
+// since flate only auto-emits once its internal block buffer fills and
+// NNTP command lines are far smaller than that.
+func (g *gzipConn) Write(p []byte) (int, error) {
+	n, err := g.zw.Write(p)
+	if err != nil {
+		return n, err
+	}
+	return n, g.zw.Flush()
+}
+
+func (g *gzipConn) Close() error {
+	err := g.zw.Close()
+	if cerr := g.Conn.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// EnableGZIP negotiates XFEATURE COMPRESS GZIP and, once the server
+// confirms with 290, wraps the remainder of the session in gzip. Every
+// existing method keeps working unchanged afterward, since they all go
+// through c.conn rather than touching the socket directly.
+func (c *Client) EnableGZIP() error {
+	if _, _, err := c.Command("XFEATURE COMPRESS GZIP", 290); err != nil {
+		return err
+	}
+
+	// Read the gzip header through c.conn's existing bufio.Reader
+	// rather than straight off c.netConn, since it may already hold
+	// bytes read ahead of the "290" response line.
+	zr, err := gzip.NewReader(c.conn.R)
+	if err != nil {
+		return err
+	}
+	gc := &gzipConn{Conn: c.netConn, zr: zr, zw: gzip.NewWriter(c.netConn)}
+
+	c.netConn = gc
+	c.conn = textproto.NewConn(gc)
+	return nil
+}
@@ -0,0 +1,105 @@
+package nntpclient
+
+import (
+	"io"
+	"net/textproto"
+	"strings"
+
+	"github.com/wathiede/go-nntp"
+)
+
+// ArticleMIME grabs an article and parses its header block into a
+// textproto.MIMEHeader, leaving the returned nntp.Article's Body
+// positioned at the start of the article content.
+func (c *Client) ArticleMIME(specifier string) (int64, string, *nntp.Article, error) {
+	if err := c.conn.PrintfLine("ARTICLE %s", specifier); err != nil {
+		return 0, "", nil, err
+	}
+	n, id, err := c.readArticleCode(220)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	header, err := c.conn.ReadMIMEHeader()
+	if err != nil {
+		return 0, "", nil, err
+	}
+	return n, id, &nntp.Article{Header: header, Body: c.conn.DotReader()}, nil
+}
+
+// HeadMIME gets the headers for an article as a fully-consumed
+// textproto.MIMEHeader.
+func (c *Client) HeadMIME(specifier string) (int64, string, textproto.MIMEHeader, error) {
+	if err := c.conn.PrintfLine("HEAD %s", specifier); err != nil {
+		return 0, "", nil, err
+	}
+	n, id, err := c.readArticleCode(221)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	lines, err := c.conn.ReadDotLines()
+	if err != nil {
+		return 0, "", nil, err
+	}
+	return n, id, parseHeaderLines(lines), nil
+}
+
+// parseHeaderLines turns the dot-terminated lines of a HEAD response
+// into a MIMEHeader.  Unlike ARTICLE, HEAD has no blank-line separator
+// before its terminating dot, so it can't be fed straight through
+// textproto.ReadMIMEHeader.
+func parseHeaderLines(lines []string) textproto.MIMEHeader {
+	header := textproto.MIMEHeader{}
+	var last string
+	for _, l := range lines {
+		if last != "" && (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) {
+			if vs := header[last]; len(vs) > 0 {
+				vs[len(vs)-1] += " " + strings.TrimSpace(l)
+			}
+			continue
+		}
+		parts := strings.SplitN(l, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		last = textproto.CanonicalMIMEHeaderKey(parts[0])
+		header.Add(last, strings.TrimSpace(parts[1]))
+	}
+	return header
+}
+
+// writeArticle writes a's headers, a blank line, and its body to w, in
+// the form PostArticle's pipe writer goroutine streams to Post.
+func writeArticle(w io.Writer, a *nntp.Article) error {
+	for k, vs := range a.Header {
+		for _, v := range vs {
+			if _, err := io.WriteString(w, k+": "+v+"\r\n"); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := io.WriteString(w, "\r\n"); err != nil {
+		return err
+	}
+	if a.Body != nil {
+		if _, err := io.Copy(w, a.Body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PostArticle posts a new article, assembling a's headers followed by
+// a blank line and its body so callers don't have to hand-assemble
+// RFC822ish text themselves.
+func (c *Client) PostArticle(a *nntp.Article) error {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeArticle(pw, a))
+	}()
+	err := c.Post(pr)
+	// Post may return early on a write error without having drained
+	// pr, which would otherwise leave the goroutine above blocked
+	// forever on its next pw.Write; closing pr here unblocks it.
+	pr.Close()
+	return err
+}
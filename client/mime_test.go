@@ -0,0 +1,152 @@
+package nntpclient
+
+import (
+	"io"
+	"net/textproto"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wathiede/go-nntp"
+)
+
+func TestArticleMIME(t *testing.T) {
+	c, server, closeAll := newTestClient(t)
+	defer closeAll()
+
+	go func() {
+		if _, err := server.ReadLine(); err != nil {
+			return
+		}
+		server.PrintfLine("220 1 <msg1> article retrieved")
+		server.PrintfLine("Subject: hi")
+		server.PrintfLine("")
+		server.PrintfLine("body")
+		server.PrintfLine(".")
+	}()
+
+	n, id, a, err := c.ArticleMIME("<msg1>")
+	if err != nil {
+		t.Fatalf("ArticleMIME: %v", err)
+	}
+	if n != 1 || id != "<msg1>" {
+		t.Errorf("ArticleMIME() n, id = %d, %q, want 1, \"<msg1>\"", n, id)
+	}
+	if got := a.Header.Get("Subject"); got != "hi" {
+		t.Errorf("Subject header = %q, want %q", got, "hi")
+	}
+	body, err := io.ReadAll(a.Body)
+	if err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+	if got := strings.TrimRight(string(body), "\r\n"); got != "body" {
+		t.Errorf("body = %q, want %q", got, "body")
+	}
+}
+
+func TestHeadMIME(t *testing.T) {
+	c, server, closeAll := newTestClient(t)
+	defer closeAll()
+
+	go func() {
+		if _, err := server.ReadLine(); err != nil {
+			return
+		}
+		server.PrintfLine("221 1 <msg1> headers follow")
+		server.PrintfLine("Subject: hi")
+		server.PrintfLine(".")
+	}()
+
+	n, id, header, err := c.HeadMIME("<msg1>")
+	if err != nil {
+		t.Fatalf("HeadMIME: %v", err)
+	}
+	if n != 1 || id != "<msg1>" {
+		t.Errorf("HeadMIME() n, id = %d, %q, want 1, \"<msg1>\"", n, id)
+	}
+	if got := header.Get("Subject"); got != "hi" {
+		t.Errorf("Subject header = %q, want %q", got, "hi")
+	}
+}
+
+func TestPostArticle(t *testing.T) {
+	c, server, closeAll := newTestClient(t)
+	defer closeAll()
+
+	serverDone := make(chan error, 1)
+	go func() {
+		if _, err := server.ReadLine(); err != nil {
+			serverDone <- err
+			return
+		}
+		if err := server.PrintfLine("340 send article"); err != nil {
+			serverDone <- err
+			return
+		}
+		lines, err := server.ReadDotLines()
+		if err != nil {
+			serverDone <- err
+			return
+		}
+		if err := server.PrintfLine("240 article posted ok"); err != nil {
+			serverDone <- err
+			return
+		}
+		if len(lines) < 2 || lines[0] != "Subject: hi" || lines[1] != "" {
+			t.Errorf("posted article lines = %q, want header then blank line", lines)
+		}
+		serverDone <- nil
+	}()
+
+	a := &nntp.Article{
+		Header: textproto.MIMEHeader{"Subject": {"hi"}},
+		Body:   strings.NewReader("body\r\n"),
+	}
+	if err := c.PostArticle(a); err != nil {
+		t.Fatalf("PostArticle: %v", err)
+	}
+
+	select {
+	case err := <-serverDone:
+		if err != nil {
+			t.Fatalf("server goroutine: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the server goroutine")
+	}
+}
+
+// TestPostArticleDoesNotLeakOnPostError reproduces a maintainer-reported
+// bug: if Post rejects the post before reading the body (e.g. the peer
+// refuses with something other than 340), the goroutine PostArticle
+// spawns to feed its internal pipe used to block forever on its next
+// write, since nothing was left to drain the pipe.
+func TestPostArticleDoesNotLeakOnPostError(t *testing.T) {
+	c, server, closeAll := newTestClient(t)
+	defer closeAll()
+
+	go func() {
+		if _, err := server.ReadLine(); err != nil {
+			return
+		}
+		server.PrintfLine("440 posting not permitted")
+	}()
+
+	before := runtime.NumGoroutine()
+	a := &nntp.Article{
+		Header: textproto.MIMEHeader{"Subject": {"hi"}},
+		Body:   strings.NewReader("body\r\n"),
+	}
+	if err := c.PostArticle(a); err == nil {
+		t.Fatal("PostArticle returned nil error for a refused post")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutine count stayed at %d (started at %d); PostArticle's writer goroutine leaked", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,102 @@
+package nntpclient
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CurrentGroup returns the name of the group selected by the most
+// recent Group or ListGroup call.
+func (c *Client) CurrentGroup() string {
+	return c.currentGroup
+}
+
+// CurrentArticle returns the article number of the session's current
+// article, as last updated by Group, Next, Last, Article, Stat or
+// ListGroup.
+func (c *Client) CurrentArticle() int64 {
+	return c.currentArticle
+}
+
+// Next advances the current article pointer to the next article in the
+// selected group.
+func (c *Client) Next() (int64, string, error) {
+	return c.advance("NEXT")
+}
+
+// Last moves the current article pointer to the previous article in
+// the selected group.
+func (c *Client) Last() (int64, string, error) {
+	return c.advance("LAST")
+}
+
+func (c *Client) advance(cmd string) (int64, string, error) {
+	if err := c.conn.PrintfLine(cmd); err != nil {
+		return 0, "", err
+	}
+	n, id, err := c.readArticleCode(223)
+	if err != nil {
+		return 0, "", err
+	}
+	c.currentArticle = n
+	return n, id, nil
+}
+
+// Stat is like Article, but only reports the article number and
+// message-id without transferring the article itself, and moves the
+// current article pointer to it.
+func (c *Client) Stat(specifier string) (int64, string, error) {
+	if err := c.conn.PrintfLine("STAT %s", specifier); err != nil {
+		return 0, "", err
+	}
+	n, id, err := c.readArticleCode(223)
+	if err != nil {
+		return 0, "", err
+	}
+	c.currentArticle = n
+	return n, id, nil
+}
+
+// ListGroup selects group (or the already-selected group, if group is
+// empty) and returns the article numbers it contains, optionally
+// restricted to the [low, high] range.  It updates CurrentGroup and
+// CurrentArticle the same way Group does.
+func (c *Client) ListGroup(group string, low, high int64) ([]int64, error) {
+	cmd := "LISTGROUP"
+	if group != "" {
+		cmd += " " + group
+	}
+	if low != 0 || high != 0 {
+		cmd += fmt.Sprintf(" %d-%d", low, high)
+	}
+
+	_, msg, err := c.Command(cmd, 211)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := c.conn.ReadDotLines()
+	if err != nil {
+		return nil, err
+	}
+
+	nums := make([]int64, 0, len(lines))
+	for _, l := range lines {
+		n, err := strconv.ParseInt(strings.TrimSpace(l), 10, 64)
+		if err != nil {
+			continue
+		}
+		nums = append(nums, n)
+	}
+
+	if parts := strings.Split(msg, " "); len(parts) == 4 {
+		c.currentGroup = parts[3]
+		if low, err := strconv.ParseInt(parts[1], 10, 64); err == nil {
+			c.currentArticle = low
+		}
+	} else if group != "" {
+		c.currentGroup = group
+	}
+
+	return nums, nil
+}
@@ -0,0 +1,105 @@
+package nntpclient
+
+import "testing"
+
+func TestNext(t *testing.T) {
+	c, server, closeAll := newTestClient(t)
+	defer closeAll()
+
+	go func() {
+		if line, err := server.ReadLine(); err != nil || line != "NEXT" {
+			return
+		}
+		server.PrintfLine("223 2 <msg2> article retrieved")
+	}()
+
+	n, id, err := c.Next()
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if n != 2 || id != "<msg2>" {
+		t.Errorf("Next() = %d, %q, want 2, \"<msg2>\"", n, id)
+	}
+	if c.CurrentArticle() != 2 {
+		t.Errorf("CurrentArticle() = %d, want 2", c.CurrentArticle())
+	}
+}
+
+func TestLast(t *testing.T) {
+	c, server, closeAll := newTestClient(t)
+	defer closeAll()
+
+	go func() {
+		if line, err := server.ReadLine(); err != nil || line != "LAST" {
+			return
+		}
+		server.PrintfLine("223 1 <msg1> article retrieved")
+	}()
+
+	n, id, err := c.Last()
+	if err != nil {
+		t.Fatalf("Last: %v", err)
+	}
+	if n != 1 || id != "<msg1>" {
+		t.Errorf("Last() = %d, %q, want 1, \"<msg1>\"", n, id)
+	}
+}
+
+func TestStat(t *testing.T) {
+	c, server, closeAll := newTestClient(t)
+	defer closeAll()
+
+	go func() {
+		if line, err := server.ReadLine(); err != nil || line != "STAT <msg1>" {
+			return
+		}
+		server.PrintfLine("223 1 <msg1> article retrieved")
+	}()
+
+	n, id, err := c.Stat("<msg1>")
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if n != 1 || id != "<msg1>" {
+		t.Errorf("Stat() = %d, %q, want 1, \"<msg1>\"", n, id)
+	}
+	if c.CurrentArticle() != 1 {
+		t.Errorf("CurrentArticle() = %d, want 1", c.CurrentArticle())
+	}
+}
+
+func TestListGroup(t *testing.T) {
+	c, server, closeAll := newTestClient(t)
+	defer closeAll()
+
+	go func() {
+		if line, err := server.ReadLine(); err != nil || line != "LISTGROUP misc.test" {
+			return
+		}
+		server.PrintfLine("211 3 1 3 misc.test")
+		server.PrintfLine("1")
+		server.PrintfLine("2")
+		server.PrintfLine("3")
+		server.PrintfLine(".")
+	}()
+
+	nums, err := c.ListGroup("misc.test", 0, 0)
+	if err != nil {
+		t.Fatalf("ListGroup: %v", err)
+	}
+	want := []int64{1, 2, 3}
+	if len(nums) != len(want) {
+		t.Fatalf("ListGroup() = %v, want %v", nums, want)
+	}
+	for i, n := range want {
+		if nums[i] != n {
+			t.Errorf("ListGroup()[%d] = %d, want %d", i, nums[i], n)
+		}
+	}
+	if c.CurrentGroup() != "misc.test" {
+		t.Errorf("CurrentGroup() = %q, want %q", c.CurrentGroup(), "misc.test")
+	}
+	if c.CurrentArticle() != 1 {
+		t.Errorf("CurrentArticle() = %d, want 1", c.CurrentArticle())
+	}
+}
@@ -0,0 +1,73 @@
+package nntpclient
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/wathiede/go-nntp"
+)
+
+// dateTimeLayout is the "YYYYMMDD HHMMSS" format NEWNEWS and NEWGROUPS
+// use, in GMT.  DATE's response has no space and is parsed separately.
+const dateTimeLayout = "20060102 150405"
+
+// IHave offers an article to a peer, sending IHAVE, streaming article
+// once the peer asks for it with 335, and expecting 235 in reply.
+func (c *Client) IHave(msgid string, article io.Reader) error {
+	if _, _, err := c.Command("IHAVE "+msgid, 335); err != nil {
+		return err
+	}
+
+	w := c.conn.DotWriter()
+	if _, err := io.Copy(w, article); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	_, _, err := c.conn.ReadCodeLine(235)
+	return err
+}
+
+// NewNews returns the message-ids of articles posted to group since the
+// given time.
+func (c *Client) NewNews(group string, since time.Time) ([]string, error) {
+	cmd := "NEWNEWS " + group + " " + since.UTC().Format(dateTimeLayout) + " GMT"
+	if _, _, err := c.Command(cmd, 230); err != nil {
+		return nil, err
+	}
+	return c.conn.ReadDotLines()
+}
+
+// NewGroups returns the groups created since the given time.
+func (c *Client) NewGroups(since time.Time) ([]nntp.Group, error) {
+	cmd := "NEWGROUPS " + since.UTC().Format(dateTimeLayout) + " GMT"
+	if _, _, err := c.Command(cmd, 231); err != nil {
+		return nil, err
+	}
+	lines, err := c.conn.ReadDotLines()
+	if err != nil {
+		return nil, err
+	}
+	rv := make([]nntp.Group, 0, len(lines))
+	for _, l := range lines {
+		if g, ok := parseGroupLine(l); ok {
+			rv = append(rv, g)
+		}
+	}
+	return rv, nil
+}
+
+// Date returns the server's idea of the current time.
+func (c *Client) Date() (time.Time, error) {
+	_, msg, err := c.Command("DATE", 111)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(msg) != 14 {
+		return time.Time{}, errors.New("nntpclient: malformed DATE response: " + msg)
+	}
+	return time.ParseInLocation("20060102150405", msg, time.UTC)
+}
@@ -0,0 +1,93 @@
+package nntpclient
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIHave(t *testing.T) {
+	c, server, closeAll := newTestClient(t)
+	defer closeAll()
+
+	go func() {
+		line, err := server.ReadLine()
+		if err != nil || line != "IHAVE <msg1>" {
+			return
+		}
+		server.PrintfLine("335 send it")
+		server.ReadDotLines()
+		server.PrintfLine("235 article transferred ok")
+	}()
+
+	if err := c.IHave("<msg1>", strings.NewReader("Subject: hi\r\n\r\nbody\r\n")); err != nil {
+		t.Fatalf("IHave: %v", err)
+	}
+}
+
+func TestNewNews(t *testing.T) {
+	c, server, closeAll := newTestClient(t)
+	defer closeAll()
+
+	go func() {
+		if _, err := server.ReadLine(); err != nil {
+			return
+		}
+		server.PrintfLine("230 list of new articles follows")
+		server.PrintfLine("<msg1>")
+		server.PrintfLine("<msg2>")
+		server.PrintfLine(".")
+	}()
+
+	ids, err := c.NewNews("misc.test", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NewNews: %v", err)
+	}
+	want := []string{"<msg1>", "<msg2>"}
+	if len(ids) != len(want) || ids[0] != want[0] || ids[1] != want[1] {
+		t.Errorf("NewNews() = %v, want %v", ids, want)
+	}
+}
+
+func TestNewGroups(t *testing.T) {
+	c, server, closeAll := newTestClient(t)
+	defer closeAll()
+
+	go func() {
+		if _, err := server.ReadLine(); err != nil {
+			return
+		}
+		server.PrintfLine("231 list of new newsgroups follows")
+		server.PrintfLine("misc.test 3 1 y")
+		server.PrintfLine(".")
+	}()
+
+	groups, err := c.NewGroups(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NewGroups: %v", err)
+	}
+	if len(groups) != 1 || groups[0].Name != "misc.test" || groups[0].High != 3 || groups[0].Low != 1 {
+		t.Errorf("NewGroups() = %+v, want one misc.test group", groups)
+	}
+}
+
+func TestDate(t *testing.T) {
+	c, server, closeAll := newTestClient(t)
+	defer closeAll()
+
+	go func() {
+		if _, err := server.ReadLine(); err != nil {
+			return
+		}
+		server.PrintfLine("111 20200102030405")
+	}()
+
+	got, err := c.Date()
+	if err != nil {
+		t.Fatalf("Date: %v", err)
+	}
+	want := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("Date() = %v, want %v", got, want)
+	}
+}
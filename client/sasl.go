@@ -0,0 +1,33 @@
+package nntpclient
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// authenticateSASLPlain runs AUTHINFO SASL PLAIN with an initial
+// response, rather than the plaintext AUTHINFO USER/PASS exchange.
+func (c *Client) authenticateSASLPlain(user, pass string) (msg string, err error) {
+	resp := base64.StdEncoding.EncodeToString([]byte("\x00" + user + "\x00" + pass))
+	if err = c.conn.PrintfLine("AUTHINFO SASL PLAIN %s", resp); err != nil {
+		return
+	}
+	_, msg, err = c.conn.ReadCodeLine(281)
+	if err != nil {
+		return
+	}
+
+	_, err = c.RefreshCapabilities()
+	return
+}
+
+// hasSASLPlain reports whether PLAIN is among the SASL mechanisms a
+// server advertised.
+func hasSASLPlain(mechs []string) bool {
+	for _, m := range mechs {
+		if strings.EqualFold(m, "PLAIN") {
+			return true
+		}
+	}
+	return false
+}
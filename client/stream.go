@@ -0,0 +1,222 @@
+package nntpclient
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+// DefaultStreamWindow is the number of CHECK/TAKETHIS commands a
+// Streamer will allow in flight before Check or TakeThis start
+// blocking the caller.
+const DefaultStreamWindow = 100
+
+// CheckResult reports the outcome of a CHECK command sent to a peer as
+// part of MODE STREAM pipelining.
+type CheckResult struct {
+	MsgID string
+	// Send is true when the peer replied 238, asking for the article to
+	// be sent with TAKETHIS.
+	Send bool
+	// Deferred is true when the peer replied 431, asking the sender to
+	// retry this message-id later.
+	Deferred bool
+	// Err is set instead of Send/Deferred if the connection failed
+	// before a response arrived.
+	Err error
+}
+
+// TakeResult reports the outcome of a TAKETHIS command.
+type TakeResult struct {
+	MsgID string
+	// Accepted is true when the peer replied 239.
+	Accepted bool
+	// Err is set instead of Accepted if the connection failed before a
+	// response arrived.
+	Err error
+}
+
+// Streamer pipelines CHECK and TAKETHIS commands to a peer that has
+// acknowledged MODE STREAM.  A background goroutine reads responses as
+// they arrive and demultiplexes them to the channel returned by the
+// Check or TakeThis call that produced them, so a caller can have many
+// commands outstanding without waiting for a reply to each one.
+type Streamer struct {
+	c      *Client
+	window int
+	sem    chan struct{}
+
+	mu      sync.Mutex
+	checks  map[string]chan CheckResult
+	takes   map[string]chan TakeResult
+	readErr error
+	done    chan struct{}
+}
+
+// StartStreaming sends MODE STREAM and, once the server acknowledges
+// it with 203, returns a Streamer ready to accept Check and TakeThis
+// calls.  window bounds the number of outstanding commands the
+// Streamer will allow before blocking; a window <= 0 uses
+// DefaultStreamWindow.
+func (c *Client) StartStreaming(window int) (*Streamer, error) {
+	if window <= 0 {
+		window = DefaultStreamWindow
+	}
+	if _, _, err := c.Command("MODE STREAM", 203); err != nil {
+		return nil, err
+	}
+	s := &Streamer{
+		c:      c,
+		window: window,
+		sem:    make(chan struct{}, window),
+		checks: map[string]chan CheckResult{},
+		takes:  map[string]chan TakeResult{},
+		done:   make(chan struct{}),
+	}
+	go s.readLoop()
+	return s, nil
+}
+
+// readLoop reads every response on the connection and routes it to the
+// channel registered by the Check or TakeThis call that is waiting on
+// that message-id.
+func (s *Streamer) readLoop() {
+	defer close(s.done)
+	for {
+		code, msg, err := s.c.conn.ReadCodeLine(-1)
+		if err != nil {
+			s.failAll(err)
+			return
+		}
+		fields := strings.Fields(msg)
+		if len(fields) == 0 {
+			continue
+		}
+		msgid := fields[0]
+		switch code {
+		case 238, 431, 438:
+			s.mu.Lock()
+			ch := s.checks[msgid]
+			delete(s.checks, msgid)
+			s.mu.Unlock()
+			if ch == nil {
+				continue
+			}
+			ch <- CheckResult{MsgID: msgid, Send: code == 238, Deferred: code == 431}
+			<-s.sem
+		case 239, 439:
+			s.mu.Lock()
+			ch := s.takes[msgid]
+			delete(s.takes, msgid)
+			s.mu.Unlock()
+			if ch == nil {
+				continue
+			}
+			ch <- TakeResult{MsgID: msgid, Accepted: code == 239}
+			<-s.sem
+		}
+	}
+}
+
+// failAll delivers err to every outstanding Check/TakeThis caller and
+// releases their semaphore slots, so a dead connection can't leave
+// Close waiting on slots nobody will ever free again.
+func (s *Streamer) failAll(err error) {
+	s.mu.Lock()
+	s.readErr = err
+	checks := s.checks
+	takes := s.takes
+	s.checks = map[string]chan CheckResult{}
+	s.takes = map[string]chan TakeResult{}
+	s.mu.Unlock()
+
+	for _, ch := range checks {
+		ch <- CheckResult{Err: err}
+		<-s.sem
+	}
+	for _, ch := range takes {
+		ch <- TakeResult{Err: err}
+		<-s.sem
+	}
+}
+
+// abandon undoes the bookkeeping Check/TakeThis did before a write
+// failed, so readLoop won't wait on a response that's never coming and
+// the semaphore slot isn't lost.
+func (s *Streamer) abandon(msgid string, check bool) {
+	s.mu.Lock()
+	if check {
+		delete(s.checks, msgid)
+	} else {
+		delete(s.takes, msgid)
+	}
+	s.mu.Unlock()
+	<-s.sem
+}
+
+// Check sends CHECK <msgid> to the peer.  The returned channel
+// receives exactly one CheckResult once the server responds.  Check
+// and TakeThis both write to the underlying connection and must only
+// be called from a single goroutine at a time; readLoop is the only
+// other goroutine that touches the connection, and it only reads.
+func (s *Streamer) Check(msgid string) (<-chan CheckResult, error) {
+	s.sem <- struct{}{}
+	ch := make(chan CheckResult, 1)
+	s.mu.Lock()
+	if s.readErr != nil {
+		err := s.readErr
+		s.mu.Unlock()
+		<-s.sem
+		return nil, err
+	}
+	s.checks[msgid] = ch
+	s.mu.Unlock()
+	if err := s.c.conn.PrintfLine("CHECK %s", msgid); err != nil {
+		s.abandon(msgid, true)
+		return nil, err
+	}
+	return ch, nil
+}
+
+// TakeThis sends TAKETHIS <msgid> followed by the article read from r.
+// The returned channel receives exactly one TakeResult once the server
+// responds.
+func (s *Streamer) TakeThis(msgid string, article io.Reader) (<-chan TakeResult, error) {
+	s.sem <- struct{}{}
+	ch := make(chan TakeResult, 1)
+	s.mu.Lock()
+	if s.readErr != nil {
+		err := s.readErr
+		s.mu.Unlock()
+		<-s.sem
+		return nil, err
+	}
+	s.takes[msgid] = ch
+	s.mu.Unlock()
+	if err := s.c.conn.PrintfLine("TAKETHIS %s", msgid); err != nil {
+		s.abandon(msgid, false)
+		return nil, err
+	}
+	w := s.c.conn.DotWriter()
+	if _, err := io.Copy(w, article); err != nil {
+		s.abandon(msgid, false)
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		s.abandon(msgid, false)
+		return nil, err
+	}
+	return ch, nil
+}
+
+// Close waits for every outstanding CHECK/TAKETHIS command to receive
+// its response, then closes the underlying connection.
+func (s *Streamer) Close() error {
+	for i := 0; i < s.window; i++ {
+		select {
+		case s.sem <- struct{}{}:
+		case <-s.done:
+		}
+	}
+	return s.c.Close()
+}
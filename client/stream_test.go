@@ -0,0 +1,110 @@
+package nntpclient
+
+import (
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestStreamer negotiates MODE STREAM over a net.Pipe and returns a
+// Streamer, the server-side textproto.Conn to drive it, and a cleanup
+// func.
+func newTestStreamer(t *testing.T, window int) (*Streamer, *textproto.Conn, func()) {
+	t.Helper()
+	clientConn, serverConn := net.Pipe()
+	c := &Client{conn: textproto.NewConn(clientConn), netConn: clientConn}
+	server := textproto.NewConn(serverConn)
+
+	negotiated := make(chan struct{})
+	go func() {
+		defer close(negotiated)
+		if _, err := server.ReadLine(); err != nil {
+			return
+		}
+		server.PrintfLine("203 streaming permitted")
+	}()
+
+	s, err := c.StartStreaming(window)
+	if err != nil {
+		t.Fatalf("StartStreaming: %v", err)
+	}
+	// Wait for the handshake goroutine to finish with server before
+	// handing it to the caller, so a later goroutine driving server
+	// doesn't race with this one's PrintfLine.
+	<-negotiated
+	return s, server, func() { clientConn.Close(); serverConn.Close() }
+}
+
+func TestStreamerCheckTakeThisRoundTrip(t *testing.T) {
+	s, server, closeAll := newTestStreamer(t, 2)
+	defer closeAll()
+
+	go func() {
+		line, err := server.ReadLine()
+		if err != nil {
+			return
+		}
+		if !strings.HasPrefix(line, "CHECK ") {
+			t.Errorf("got %q, want a CHECK command", line)
+		}
+		server.PrintfLine("238 <msg1> send it")
+	}()
+
+	ch, err := s.Check("<msg1>")
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	select {
+	case res := <-ch:
+		if res.Err != nil || !res.Send {
+			t.Errorf("Check result = %+v, want Send=true, Err=nil", res)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for CheckResult")
+	}
+}
+
+// TestStreamerCloseAfterConnectionDrop reproduces a maintainer-reported
+// bug: if the connection dies while a Check/TakeThis is outstanding,
+// failAll must release its semaphore slot or Close hangs forever
+// waiting to refill a window it can no longer drain.
+func TestStreamerCloseAfterConnectionDrop(t *testing.T) {
+	s, server, closeAll := newTestStreamer(t, 1)
+	defer closeAll()
+
+	resultCh := make(chan CheckResult, 1)
+	go func() {
+		ch, err := s.Check("<msg2>")
+		if err != nil {
+			resultCh <- CheckResult{Err: err}
+			return
+		}
+		resultCh <- <-ch
+	}()
+
+	// Let the CHECK command land, then sever the connection before
+	// responding so readLoop observes an error instead of a reply.
+	if _, err := server.ReadLine(); err != nil {
+		t.Fatalf("server ReadLine: %v", err)
+	}
+	server.Close()
+
+	select {
+	case res := <-resultCh:
+		if res.Err == nil {
+			t.Fatal("Check result has no error after the connection died")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for failAll to deliver an error")
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- s.Close() }()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close hung after the connection died mid-flight")
+	}
+}
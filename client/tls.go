@@ -0,0 +1,75 @@
+package nntpclient
+
+import (
+	"crypto/tls"
+	"net/textproto"
+)
+
+// DialTLS connects a client to an NNTP server over an implicit-TLS
+// port, such as 563.  Use StartTLS instead for a plaintext connection
+// that upgrades via STARTTLS.
+func DialTLS(network, addr string, cfg *tls.Config) (*Client, error) {
+	conn, err := tls.Dial(network, addr, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	text := textproto.NewConn(conn)
+	_, msg, err := text.ReadCodeLine(200)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		conn:    text,
+		netConn: conn,
+		Banner:  msg,
+	}, nil
+}
+
+// StartTLS negotiates STARTTLS on an existing plaintext connection and
+// reissues CAPABILITIES afterward, since the server may advertise
+// different capabilities once the channel is encrypted.
+func (c *Client) StartTLS(cfg *tls.Config) error {
+	if _, _, err := c.Command("STARTTLS", 382); err != nil {
+		return err
+	}
+
+	tlsConn := tls.Client(c.netConn, cfg)
+	if err := tlsConn.Handshake(); err != nil {
+		return err
+	}
+	c.netConn = tlsConn
+	c.conn = textproto.NewConn(tlsConn)
+
+	_, err := c.RefreshCapabilities()
+	return err
+}
+
+// isEncrypted reports whether the client's current connection is
+// wrapped in TLS, either via DialTLS or a prior successful StartTLS.
+// It unwraps gzipConn first, since EnableGZIP replaces c.netConn with
+// one on top of whatever transport was already in use.
+func (c *Client) isEncrypted() bool {
+	conn := c.netConn
+	if gc, ok := conn.(*gzipConn); ok {
+		conn = gc.Conn
+	}
+	_, ok := conn.(*tls.Conn)
+	return ok
+}
+
+// DialStartTLS dials a plaintext connection and negotiates STARTTLS in
+// one call, for servers that only offer the upgrade rather than an
+// implicit-TLS port.
+func DialStartTLS(net, addr string, cfg *tls.Config) (*Client, error) {
+	c, err := New(net, addr)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.StartTLS(cfg); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,96 @@
+package nntpclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/textproto"
+	"testing"
+	"time"
+)
+
+// generateTestCert returns a self-signed certificate valid for
+// "localhost", suitable for a tls.Config used only within a test.
+func generateTestCert(t *testing.T) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// TestClientStartTLS drives StartTLS over a net.Pipe, with the server
+// side upgrading to TLS and then answering the post-upgrade
+// CAPABILITIES call StartTLS makes to refresh the session's feature
+// set.
+func TestClientStartTLS(t *testing.T) {
+	cert := generateTestCert(t)
+	clientConn, serverConn := net.Pipe()
+	c := &Client{conn: textproto.NewConn(clientConn), netConn: clientConn}
+
+	serverDone := make(chan error, 1)
+	go func() {
+		server := textproto.NewConn(serverConn)
+		if _, err := server.ReadLine(); err != nil {
+			serverDone <- err
+			return
+		}
+		if err := server.PrintfLine("382 continue with TLS negotiation"); err != nil {
+			serverDone <- err
+			return
+		}
+
+		tlsServer := tls.Server(serverConn, &tls.Config{Certificates: []tls.Certificate{cert}})
+		server = textproto.NewConn(tlsServer)
+		if _, err := server.ReadLine(); err != nil {
+			serverDone <- err
+			return
+		}
+		if err := server.PrintfLine("101 Capability list:"); err != nil {
+			serverDone <- err
+			return
+		}
+		if err := server.PrintfLine("VERSION 2"); err != nil {
+			serverDone <- err
+			return
+		}
+		if err := server.PrintfLine("."); err != nil {
+			serverDone <- err
+			return
+		}
+		serverDone <- nil
+	}()
+
+	if err := c.StartTLS(&tls.Config{InsecureSkipVerify: true}); err != nil {
+		t.Fatalf("StartTLS: %v", err)
+	}
+	if !c.isEncrypted() {
+		t.Error("isEncrypted() = false after a successful StartTLS")
+	}
+
+	select {
+	case err := <-serverDone:
+		if err != nil {
+			t.Fatalf("server goroutine: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the server goroutine")
+	}
+}